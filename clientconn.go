@@ -0,0 +1,12 @@
+package gortsplib
+
+// clientConnState is the state of a ClientConn.
+type clientConnState int
+
+const (
+	clientConnStateInitial clientConnState = iota
+	clientConnStatePrePlay
+	clientConnStatePlay
+	clientConnStatePreRecord
+	clientConnStateRecord
+)
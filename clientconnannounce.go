@@ -0,0 +1,40 @@
+package gortsplib
+
+import (
+	"fmt"
+
+	"github.com/aler9/gortsplib/pkg/base"
+)
+
+// Announce writes an ANNOUNCE request and reads a Response.
+// This can be called only before Setup() and Record(), in order to
+// publish a stream rather than read one.
+func (c *ClientConn) Announce(u *base.URL, tracks Tracks) (*base.Response, error) {
+	err := c.checkState(map[clientConnState]struct{}{
+		clientConnStateInitial: {},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.Do(&base.Request{
+		Method: base.Announce,
+		URL:    u,
+		Header: base.Header{
+			"Content-Type": base.HeaderValue{"application/sdp"},
+		},
+		Body: tracks.Write(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != base.StatusOK {
+		return nil, fmt.Errorf("bad status code: %d (%s)", res.StatusCode, res.StatusMessage)
+	}
+
+	c.streamURL = u
+	c.state = clientConnStatePreRecord
+
+	return res, nil
+}
@@ -0,0 +1,125 @@
+package gortsplib
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+
+	"github.com/aler9/gortsplib/pkg/base"
+)
+
+const defaultRedirectMax = 5
+
+// Describe writes a DESCRIBE request and reads a Response.
+// If the response contains a 3xx status code alongside a Location header,
+// the connection is transparently redirected to the new URL (re-running
+// the authentication handshake if necessary) up to ClientConf.RedirectMax
+// times, as if the final server had answered the original request.
+func (c *ClientConn) Describe() (Tracks, *base.Response, error) {
+	redirectMax := c.c.RedirectMax
+	if redirectMax == 0 {
+		redirectMax = defaultRedirectMax
+	}
+
+	for i := 0; i <= redirectMax; i++ {
+		res, err := c.Do(&base.Request{
+			Method: base.Describe,
+			URL:    c.streamURL,
+			Header: base.Header{
+				"Accept": base.HeaderValue{"application/sdp"},
+			},
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if isRedirect(res.StatusCode) {
+			if i == redirectMax {
+				break
+			}
+
+			u, err := redirectLocation(res.Header)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			err = c.redirectTo(u)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			continue
+		}
+
+		if res.StatusCode != base.StatusOK {
+			return nil, res, fmt.Errorf("bad status code: %d (%s)", res.StatusCode, res.StatusMessage)
+		}
+
+		tracks, err := ReadTracks(res.Body)
+		if err != nil {
+			return nil, res, err
+		}
+
+		return tracks, res, nil
+	}
+
+	return nil, nil, fmt.Errorf("too many redirects")
+}
+
+// isRedirect reports whether a status code is a 3xx that carries a
+// Location header (300-305), as opposed to e.g. 304 Not Modified.
+func isRedirect(statusCode int) bool {
+	return statusCode != base.StatusNotModified &&
+		statusCode >= base.StatusMultipleChoices && statusCode <= base.StatusUseProxy
+}
+
+// redirectLocation extracts and parses the Location header of a redirect
+// response.
+func redirectLocation(header base.Header) (*base.URL, error) {
+	location, ok := header["Location"]
+	if !ok || len(location) != 1 {
+		return nil, fmt.Errorf("redirect response without a Location header")
+	}
+
+	u, err := base.ParseURL(location[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid Location header: %v", err)
+	}
+
+	return u, nil
+}
+
+// URL returns the URL of the connection, which is the one passed to Dial(),
+// or the last one followed by a DESCRIBE redirect.
+func (c *ClientConn) URL() *base.URL {
+	return c.streamURL
+}
+
+// redirectTo closes the current connection and opens a new one to u,
+// so that subsequent requests (Setup, Play, ...) are sent to the
+// redirected host using its control URLs.
+func (c *ClientConn) redirectTo(u *base.URL) error {
+	if c.nconn != nil {
+		c.nconn.Close()
+	}
+
+	nconn, err := net.DialTimeout("tcp", u.Host, c.c.ReadTimeout)
+	if err != nil {
+		return err
+	}
+
+	c.nconn = nconn
+	c.br = bufio.NewReaderSize(nconn, clientReadBufferSize)
+	c.bw = bufio.NewWriterSize(nconn, clientWriteBufferSize)
+	c.streamURL = u
+	c.session = ""
+	c.sessionTimeout = nil
+
+	// the realm/nonce computed for the original authority are worthless
+	// against a different redirected server (or may not even be required
+	// there); drop them so Do() performs a fresh challenge/response
+	// handshake against u instead of reusing stale credentials.
+	c.sender = nil
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package gortsplib
+
+import (
+	"testing"
+
+	"github.com/aler9/gortsplib/pkg/base"
+)
+
+func TestIsRedirect(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		code int
+		want bool
+	}{
+		{"ok", 200, false},
+		{"moved permanently", 301, true},
+		{"found", 302, true},
+		{"use proxy", 305, true},
+		{"not modified", 304, false},
+		{"server error", 500, false},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			if got := isRedirect(ca.code); got != ca.want {
+				t.Errorf("isRedirect(%d) = %v, want %v", ca.code, got, ca.want)
+			}
+		})
+	}
+}
+
+func TestRedirectLocation(t *testing.T) {
+	u, err := redirectLocation(base.Header{"Location": base.HeaderValue{"rtsp://example.com/stream"}})
+	if err != nil {
+		t.Fatalf("redirectLocation() failed: %v", err)
+	}
+	if u.Host != "example.com" {
+		t.Errorf("Host = %q, want %q", u.Host, "example.com")
+	}
+}
+
+func TestRedirectLocationMissing(t *testing.T) {
+	if _, err := redirectLocation(base.Header{}); err == nil {
+		t.Fatal("expected an error for a redirect without a Location header")
+	}
+}
+
+func TestRedirectLocationInvalid(t *testing.T) {
+	if _, err := redirectLocation(base.Header{"Location": base.HeaderValue{"://not-a-url"}}); err == nil {
+		t.Fatal("expected an error for an invalid Location header")
+	}
+}
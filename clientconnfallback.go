@@ -0,0 +1,103 @@
+package gortsplib
+
+import (
+	"fmt"
+
+	"github.com/aler9/gortsplib/pkg/base"
+	"github.com/aler9/gortsplib/pkg/headers"
+)
+
+// StreamProtocolAutomatic tells ClientConn to start reading with UDP and,
+// if no packet is received within ReadTimeout, transparently fall back to
+// TCP by tearing down and re-setting-up every track with an interleaved
+// transport.
+const StreamProtocolAutomatic = StreamProtocol(100)
+
+// OnFallbackToTCP sets a callback that is called right before ClientConn
+// switches a UDP session to TCP because no packets were received.
+// This can be called at any time before Play().
+func (c *ClientConn) OnFallbackToTCP(cb func()) {
+	c.onFallbackToTCP = cb
+}
+
+// fallbackToTCP tears down the current UDP transport and re-issues a
+// SETUP with an interleaved transport for every track, then switches
+// the read loop to TCP.
+func (c *ClientConn) fallbackToTCP() error {
+	for trackID := range c.udpRtpListeners {
+		c.udpRtpListeners[trackID].stop()
+		c.udpRtcpListeners[trackID].stop()
+	}
+	c.udpRtpListeners = nil
+	c.udpRtcpListeners = nil
+
+	res, err := c.Do(&base.Request{
+		Method: base.Teardown,
+		URL:    c.streamURL,
+		Header: base.Header{
+			"Session": c.sessionHeader(),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != base.StatusOK {
+		return fmt.Errorf("bad status code: %d (%s)", res.StatusCode, res.StatusMessage)
+	}
+
+	// TEARDOWN ends the session server-side; the following SETUPs must
+	// establish a fresh one instead of advertising the now-invalid
+	// session ID, or servers reply with 454 Session Not Found.
+	c.session = ""
+	c.sessionTimeout = nil
+
+	for trackID, track := range c.tracks {
+		res, err := c.Do(&base.Request{
+			Method: base.Setup,
+			URL:    track.BaseURL,
+			Header: base.Header{
+				"Transport": fallbackTCPTransport(trackID).Write(),
+				"Session":   c.sessionHeader(),
+			},
+		})
+		if err != nil {
+			return err
+		}
+		if res.StatusCode != base.StatusOK {
+			return fmt.Errorf("bad status code: %d (%s)", res.StatusCode, res.StatusMessage)
+		}
+
+		c.captureSessionHeader(res)
+	}
+
+	tcp := StreamProtocolTCP
+	c.streamProtocol = &tcp
+
+	// the session created by the SETUPs above is in the "Ready" state;
+	// without a fresh PLAY the server never resumes sending media on the new TCP
+	// channel, so the fallback would tear the stream down instead of
+	// rescuing it.
+	_, err = c.play()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// fallbackTCPTransport builds the interleaved Transport header used to
+// re-SETUP a track over TCP.
+func fallbackTCPTransport(trackID int) headers.Transport {
+	return headers.Transport{
+		Protocol: StreamProtocolTCP,
+		Delivery: func() *base.StreamDelivery {
+			v := base.StreamDeliveryUnicast
+			return &v
+		}(),
+		Mode: func() *headers.TransportMode {
+			v := headers.TransportModePlay
+			return &v
+		}(),
+		InterleavedIDs: &[2]int{trackID * 2, (trackID * 2) + 1},
+	}
+}
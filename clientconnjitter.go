@@ -0,0 +1,59 @@
+package gortsplib
+
+import (
+	"encoding/binary"
+
+	"github.com/aler9/gortsplib/pkg/jitterbuffer"
+)
+
+// setupJitterBuffers allocates a jitter buffer per track if
+// ClientConf.JitterBuffer is non-zero. cb is the original, un-wrapped
+// callback passed to OnFrame: released packets must reach it directly,
+// not the jitter-buffered wrapper, or they would loop back into the buffer.
+func (c *ClientConn) setupJitterBuffers(cb func(int, StreamType, []byte)) {
+	if c.c.JitterBuffer == 0 {
+		return
+	}
+
+	c.jitterBuffers = make(map[int]*jitterbuffer.JitterBuffer)
+
+	for trackID := range c.tracks {
+		trackID := trackID
+		c.jitterBuffers[trackID] = jitterbuffer.New(c.c.JitterBuffer, c.c.JitterBufferDelay,
+			func(payload []byte) {
+				cb(trackID, StreamTypeRtp, payload)
+			})
+	}
+}
+
+// jitterBufferedReadCB wraps cb so that RTP frames of a track with a
+// configured jitter buffer are pushed through it instead of being
+// delivered directly, on both the UDP and TCP read paths.
+func (c *ClientConn) jitterBufferedReadCB(cb func(int, StreamType, []byte)) func(int, StreamType, []byte) {
+	if c.jitterBuffers == nil {
+		return cb
+	}
+
+	return func(trackID int, streamType StreamType, payload []byte) {
+		if streamType != StreamTypeRtp {
+			cb(trackID, streamType, payload)
+			return
+		}
+
+		jb, ok := c.jitterBuffers[trackID]
+		if !ok || len(payload) < 4 {
+			cb(trackID, streamType, payload)
+			return
+		}
+
+		jb.Push(binary.BigEndian.Uint16(payload[2:4]), payload)
+	}
+}
+
+// flushJitterBuffers releases every packet still held by the jitter
+// buffers, so that no frame is lost on shutdown.
+func (c *ClientConn) flushJitterBuffers() {
+	for _, jb := range c.jitterBuffers {
+		jb.Flush()
+	}
+}
@@ -18,9 +18,20 @@ func (c *ClientConn) Play() (*base.Response, error) {
 		return nil, err
 	}
 
+	return c.play()
+}
+
+// play writes a PLAY request and reads a Response, without checking the
+// current state. Used by Play() and by fallbackToTCP(), which must
+// re-issue PLAY after a TEARDOWN/SETUP pair while c.state is already
+// clientConnStatePlay.
+func (c *ClientConn) play() (*base.Response, error) {
 	res, err := c.Do(&base.Request{
 		Method: base.Play,
 		URL:    c.streamURL,
+		Header: base.Header{
+			"Session": c.sessionHeader(),
+		},
 	})
 	if err != nil {
 		return nil, err
@@ -34,9 +45,15 @@ func (c *ClientConn) Play() (*base.Response, error) {
 }
 
 func (c *ClientConn) backgroundPlayUDP(onFrameDone chan error) {
-	defer close(c.backgroundDone)
-
 	var returnError error
+	fellBackToTCP := false
+
+	defer func() {
+		if fellBackToTCP {
+			return
+		}
+		close(c.backgroundDone)
+	}()
 
 	defer func() {
 		for trackID := range c.udpRtpListeners {
@@ -44,6 +61,11 @@ func (c *ClientConn) backgroundPlayUDP(onFrameDone chan error) {
 			c.udpRtcpListeners[trackID].stop()
 		}
 
+		if fellBackToTCP {
+			return
+		}
+
+		c.flushJitterBuffers()
 		onFrameDone <- returnError
 	}()
 
@@ -79,7 +101,7 @@ func (c *ClientConn) backgroundPlayUDP(onFrameDone chan error) {
 	reportTicker := time.NewTicker(clientReceiverReportPeriod)
 	defer reportTicker.Stop()
 
-	keepaliveTicker := time.NewTicker(clientUDPKeepalivePeriod)
+	keepaliveTicker := time.NewTicker(c.keepalivePeriod())
 	defer keepaliveTicker.Stop()
 
 	checkStreamTicker := time.NewTicker(clientUDPCheckStreamPeriod)
@@ -110,7 +132,10 @@ func (c *ClientConn) backgroundPlayUDP(onFrameDone chan error) {
 					return base.Options
 				}(),
 				// use the stream path, otherwise some cameras do not reply
-				URL:          c.streamURL,
+				URL: c.streamURL,
+				Header: base.Header{
+					"Session": c.sessionHeader(),
+				},
 				SkipResponse: true,
 			})
 			if err != nil {
@@ -129,6 +154,23 @@ func (c *ClientConn) backgroundPlayUDP(onFrameDone chan error) {
 				if now.Sub(last) >= c.c.ReadTimeout {
 					c.nconn.SetReadDeadline(time.Now())
 					<-readerDone
+
+					if c.c.StreamProtocol != nil && *c.c.StreamProtocol == StreamProtocolAutomatic {
+						if c.onFallbackToTCP != nil {
+							c.onFallbackToTCP()
+						}
+
+						err := c.fallbackToTCP()
+						if err != nil {
+							returnError = err
+							return
+						}
+
+						fellBackToTCP = true
+						go c.backgroundPlayTCP(onFrameDone)
+						return
+					}
+
 					returnError = fmt.Errorf("no packets received recently (maybe there's a firewall/NAT in between)")
 					return
 				}
@@ -147,6 +189,7 @@ func (c *ClientConn) backgroundPlayTCP(onFrameDone chan error) {
 	var returnError error
 
 	defer func() {
+		c.flushJitterBuffers()
 		onFrameDone <- returnError
 	}()
 
@@ -164,6 +207,10 @@ func (c *ClientConn) backgroundPlayTCP(onFrameDone chan error) {
 
 			c.rtcpReceivers[frame.TrackID].ProcessFrame(time.Now(), frame.StreamType, frame.Content)
 
+			if frame.StreamType == StreamTypeRtcp {
+				c.processRTCP(frame.TrackID, frame.Content)
+			}
+
 			c.readCB(frame.TrackID, frame.StreamType, frame.Content)
 		}
 	}()
@@ -227,6 +274,8 @@ func (c *ClientConn) OnFrame(cb func(int, StreamType, []byte)) chan error {
 	c.readCB = cb
 	c.backgroundTerminate = make(chan struct{})
 	c.backgroundDone = make(chan struct{})
+	c.setupJitterBuffers(cb)
+	c.readCB = c.jitterBufferedReadCB(cb)
 
 	if *c.streamProtocol == StreamProtocolUDP {
 		go c.backgroundPlayUDP(onFrameDone)
@@ -0,0 +1,270 @@
+package gortsplib
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aler9/gortsplib/pkg/base"
+	"github.com/aler9/gortsplib/pkg/rtcpsender"
+)
+
+// Record writes a RECORD request and reads a Response.
+// This can be called only after Announce() and Setup().
+func (c *ClientConn) Record() (*base.Response, error) {
+	err := c.checkState(map[clientConnState]struct{}{
+		clientConnStatePreRecord: {},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.Do(&base.Request{
+		Method: base.Record,
+		URL:    c.streamURL,
+		Header: base.Header{
+			"Session": c.sessionHeader(),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != base.StatusOK {
+		return nil, fmt.Errorf("bad status code: %d (%s)", res.StatusCode, res.StatusMessage)
+	}
+
+	c.captureSessionHeader(res)
+
+	c.state = clientConnStateRecord
+	c.backgroundTerminate = make(chan struct{})
+	c.backgroundDone = make(chan struct{})
+	// channel is buffered, since listening to it is not mandatory
+	c.recordDone = make(chan error, 1)
+
+	c.rtcpSenders = make(map[int]*rtcpsender.RTCPSender)
+	for trackID, track := range c.tracks {
+		c.rtcpSenders[trackID] = rtcpsender.New(track.ClockRate())
+	}
+
+	if *c.streamProtocol == StreamProtocolUDP {
+		go c.backgroundRecordUDP(c.recordDone)
+	} else {
+		go c.backgroundRecordTCP(c.recordDone)
+	}
+
+	return res, nil
+}
+
+// OnRecordError returns a channel that receives the error that caused the
+// background write/keepalive routine to stop (a read error on the
+// connection, e.g. the server closing it, or a keepalive failure),
+// mirroring OnFrame's contract.
+// This can be called only after Record().
+func (c *ClientConn) OnRecordError() chan error {
+	return c.recordDone
+}
+
+// WriteFrame writes a frame (RTP or RTCP) to the server.
+// This can be called only after Record().
+func (c *ClientConn) WriteFrame(trackID int, streamType StreamType, content []byte) error {
+	err := c.checkState(map[clientConnState]struct{}{
+		clientConnStateRecord: {},
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, ok := c.tracks[trackID]; !ok {
+		return fmt.Errorf("invalid track id (%d)", trackID)
+	}
+
+	if streamType == StreamTypeRtp {
+		c.rtcpSenders[trackID].ProcessPacket(time.Now(), content)
+	}
+
+	if *c.streamProtocol == StreamProtocolUDP {
+		if streamType == StreamTypeRtp {
+			c.udpRtpListeners[trackID].write(content)
+		} else {
+			c.udpRtcpListeners[trackID].write(content)
+		}
+		return nil
+	}
+
+	// c.bw is also written by backgroundRecordTCP's RTCP report ticker;
+	// serialize access so that a report and a WriteFrame() call never
+	// interleave their bytes on the wire.
+	c.bwMutex.Lock()
+	defer c.bwMutex.Unlock()
+
+	c.nconn.SetWriteDeadline(time.Now().Add(c.c.WriteTimeout))
+	frame := base.InterleavedFrame{
+		TrackID:    trackID,
+		StreamType: streamType,
+		Content:    content,
+	}
+	return frame.Write(c.bw)
+}
+
+func (c *ClientConn) backgroundRecordUDP(recordDone chan error) {
+	var returnError error
+
+	defer close(c.backgroundDone)
+	defer func() {
+		recordDone <- returnError
+	}()
+
+	for trackID := range c.udpRtpListeners {
+		c.udpRtpListeners[trackID].start()
+		c.udpRtcpListeners[trackID].start()
+	}
+
+	defer func() {
+		for trackID := range c.udpRtpListeners {
+			c.udpRtpListeners[trackID].stop()
+			c.udpRtcpListeners[trackID].stop()
+		}
+	}()
+
+	// disable deadline
+	c.nconn.SetReadDeadline(time.Time{})
+
+	readerDone := make(chan error)
+	go func() {
+		for {
+			var res base.Response
+			err := res.Read(c.br)
+			if err != nil {
+				readerDone <- err
+				return
+			}
+		}
+	}()
+
+	reportTicker := time.NewTicker(clientReceiverReportPeriod)
+	defer reportTicker.Stop()
+
+	keepaliveTicker := time.NewTicker(c.keepalivePeriod())
+	defer keepaliveTicker.Stop()
+
+	for {
+		select {
+		case <-c.backgroundTerminate:
+			c.nconn.SetReadDeadline(time.Now())
+			<-readerDone
+			returnError = fmt.Errorf("terminated")
+			return
+
+		case <-reportTicker.C:
+			now := time.Now()
+			for trackID := range c.rtcpSenders {
+				r := c.rtcpSenders[trackID].Report(now)
+				if r != nil {
+					c.udpRtcpListeners[trackID].write(r)
+				}
+			}
+
+		case <-keepaliveTicker.C:
+			_, err := c.Do(&base.Request{
+				Method: func() base.Method {
+					// the vlc integrated rtsp server requires GET_PARAMETER
+					if c.getParameterSupported {
+						return base.GetParameter
+					}
+					return base.Options
+				}(),
+				// use the stream path, otherwise some cameras do not reply
+				URL: c.streamURL,
+				Header: base.Header{
+					"Session": c.sessionHeader(),
+				},
+				SkipResponse: true,
+			})
+			if err != nil {
+				c.nconn.SetReadDeadline(time.Now())
+				<-readerDone
+				returnError = err
+				return
+			}
+
+		case err := <-readerDone:
+			returnError = err
+			return
+		}
+	}
+}
+
+func (c *ClientConn) backgroundRecordTCP(recordDone chan error) {
+	var returnError error
+
+	defer close(c.backgroundDone)
+	defer func() {
+		recordDone <- returnError
+	}()
+
+	readerDone := make(chan error)
+	go func() {
+		for {
+			frame := base.InterleavedFrame{
+				Content: c.tcpFrameBuffer.Next(),
+			}
+			err := frame.Read(c.br)
+			if err != nil {
+				readerDone <- err
+				return
+			}
+
+			// the server is free to talk back on the same interleaved
+			// channel we're writing RTP/RTCP on, most commonly with RTCP
+			// receiver reports.
+			if frame.StreamType == StreamTypeRtcp {
+				c.processRTCP(frame.TrackID, frame.Content)
+			}
+		}
+	}()
+
+	reportTicker := time.NewTicker(clientReceiverReportPeriod)
+	defer reportTicker.Stop()
+
+	// for some reason, SetReadDeadline() must always be called in the same
+	// goroutine, otherwise Read() freezes.
+	// therefore, we call it with a ticker.
+	deadlineTicker := time.NewTicker(1 * time.Second)
+	defer deadlineTicker.Stop()
+
+	for {
+		select {
+		case <-deadlineTicker.C:
+			c.nconn.SetReadDeadline(time.Now().Add(c.c.ReadTimeout))
+
+		case <-c.backgroundTerminate:
+			c.nconn.SetReadDeadline(time.Now())
+			<-readerDone
+			returnError = fmt.Errorf("terminated")
+			return
+
+		case <-reportTicker.C:
+			now := time.Now()
+			for trackID := range c.rtcpSenders {
+				r := c.rtcpSenders[trackID].Report(now)
+				if r == nil {
+					continue
+				}
+
+				c.bwMutex.Lock()
+				c.nconn.SetWriteDeadline(time.Now().Add(c.c.WriteTimeout))
+				frame := base.InterleavedFrame{
+					TrackID:    trackID,
+					StreamType: StreamTypeRtcp,
+					Content:    r,
+				}
+				frame.Write(c.bw)
+				c.bwMutex.Unlock()
+			}
+
+		case err := <-readerDone:
+			returnError = err
+			return
+		}
+	}
+}
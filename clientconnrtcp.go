@@ -0,0 +1,84 @@
+package gortsplib
+
+import (
+	"github.com/pion/rtcp"
+
+	"github.com/aler9/gortsplib/pkg/jitterbuffer"
+)
+
+// TrackStats is a snapshot of the statistics collected for a single track,
+// built out of the RTCP sender reports and received RTP packets.
+type TrackStats struct {
+	// NTP timestamp of the last sender report.
+	LastSenderReportNTP uint64
+
+	// RTP timestamp of the last sender report.
+	LastSenderReportRTP uint32
+
+	// estimated jitter, in RTP clock units.
+	Jitter float64
+
+	// cumulative number of packets lost, as reported by RTCP.
+	PacketsLost uint32
+
+	// counters collected by the jitter buffer, if enabled (see ClientConf.JitterBuffer).
+	JitterBuffer jitterbuffer.Counters
+}
+
+// OnRTCP sets a callback that is called when a RTCP packet is received.
+// This can be called only after Play().
+func (c *ClientConn) OnRTCP(cb func(trackID int, pkt rtcp.Packet)) {
+	c.onRTCP = cb
+}
+
+// TrackStats returns a snapshot of the statistics collected for a track.
+func (c *ClientConn) TrackStats(trackID int) TrackStats {
+	c.statsMutex.Lock()
+	defer c.statsMutex.Unlock()
+
+	stats := c.trackStats[trackID]
+
+	if jb, ok := c.jitterBuffers[trackID]; ok {
+		stats.JitterBuffer = jb.Stats()
+	}
+
+	return stats
+}
+
+func (c *ClientConn) processRTCP(trackID int, byts []byte) {
+	packets, err := rtcp.Unmarshal(byts)
+	if err != nil {
+		return
+	}
+
+	c.statsMutex.Lock()
+	stats := c.trackStats[trackID]
+	for _, pkt := range packets {
+		applyRTCPPacket(&stats, pkt)
+	}
+	c.trackStats[trackID] = stats
+	c.statsMutex.Unlock()
+
+	if c.onRTCP != nil {
+		for _, pkt := range packets {
+			c.onRTCP(trackID, pkt)
+		}
+	}
+}
+
+// applyRTCPPacket merges the information carried by a single parsed RTCP
+// packet into stats. It is a pure function, kept separate from
+// processRTCP so it can be unit-tested without a ClientConn.
+func applyRTCPPacket(stats *TrackStats, pkt rtcp.Packet) {
+	switch p := pkt.(type) {
+	case *rtcp.SenderReport:
+		stats.LastSenderReportNTP = p.NTPTime
+		stats.LastSenderReportRTP = p.RTPTime
+
+	case *rtcp.ReceiverReport:
+		if len(p.Reports) > 0 {
+			stats.Jitter = float64(p.Reports[0].Jitter)
+			stats.PacketsLost = p.Reports[0].TotalLost
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package gortsplib
+
+import (
+	"testing"
+
+	"github.com/pion/rtcp"
+)
+
+func TestApplyRTCPPacketSenderReport(t *testing.T) {
+	var stats TrackStats
+
+	applyRTCPPacket(&stats, &rtcp.SenderReport{
+		NTPTime: 123456789,
+		RTPTime: 90000,
+	})
+
+	if stats.LastSenderReportNTP != 123456789 {
+		t.Errorf("LastSenderReportNTP = %d, want 123456789", stats.LastSenderReportNTP)
+	}
+	if stats.LastSenderReportRTP != 90000 {
+		t.Errorf("LastSenderReportRTP = %d, want 90000", stats.LastSenderReportRTP)
+	}
+}
+
+func TestApplyRTCPPacketReceiverReport(t *testing.T) {
+	var stats TrackStats
+
+	applyRTCPPacket(&stats, &rtcp.ReceiverReport{
+		Reports: []rtcp.ReceptionReport{
+			{Jitter: 42, TotalLost: 7},
+		},
+	})
+
+	if stats.Jitter != 42 {
+		t.Errorf("Jitter = %v, want 42", stats.Jitter)
+	}
+	if stats.PacketsLost != 7 {
+		t.Errorf("PacketsLost = %d, want 7", stats.PacketsLost)
+	}
+}
+
+func TestApplyRTCPPacketReceiverReportNoReports(t *testing.T) {
+	stats := TrackStats{Jitter: 1, PacketsLost: 2}
+
+	// a ReceiverReport with no per-source blocks must not overwrite
+	// the previously collected values.
+	applyRTCPPacket(&stats, &rtcp.ReceiverReport{})
+
+	if stats.Jitter != 1 || stats.PacketsLost != 2 {
+		t.Errorf("got %+v, want unchanged stats", stats)
+	}
+}
+
+func TestApplyRTCPPacketUnknownType(t *testing.T) {
+	stats := TrackStats{Jitter: 1}
+
+	applyRTCPPacket(&stats, &rtcp.Goodbye{})
+
+	if stats.Jitter != 1 {
+		t.Errorf("got %+v, want unchanged stats", stats)
+	}
+}
+
+func TestApplyRTCPPacketMultiplePackets(t *testing.T) {
+	var stats TrackStats
+
+	packets := []rtcp.Packet{
+		&rtcp.SenderReport{NTPTime: 1, RTPTime: 2},
+		&rtcp.ReceiverReport{Reports: []rtcp.ReceptionReport{{Jitter: 3, TotalLost: 4}}},
+	}
+
+	for _, pkt := range packets {
+		applyRTCPPacket(&stats, pkt)
+	}
+
+	if stats.LastSenderReportNTP != 1 || stats.LastSenderReportRTP != 2 {
+		t.Errorf("sender report fields not applied: %+v", stats)
+	}
+	if stats.Jitter != 3 || stats.PacketsLost != 4 {
+		t.Errorf("receiver report fields not applied: %+v", stats)
+	}
+}
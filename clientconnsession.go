@@ -0,0 +1,49 @@
+package gortsplib
+
+import (
+	"time"
+
+	"github.com/aler9/gortsplib/pkg/base"
+	"github.com/aler9/gortsplib/pkg/headers"
+)
+
+// keepalivePeriod returns the period at which GET_PARAMETER/OPTIONS requests
+// must be sent to keep the session alive. If the server advertised a session
+// timeout via the Session header, half of it is used, as recommended by
+// RFC 2326; otherwise, or if the advertised timeout is too small to derive a
+// sane period from (e.g. a malformed "timeout=0"), the hardcoded default is
+// used instead.
+func (c *ClientConn) keepalivePeriod() time.Duration {
+	if c.sessionTimeout != nil && *c.sessionTimeout >= 2 {
+		return (time.Duration(*c.sessionTimeout) * time.Second) / 2
+	}
+	return clientUDPKeepalivePeriod
+}
+
+// captureSessionHeader reads the Session header of a response, if present,
+// storing its ID (so it can be sent back on subsequent requests) and its
+// optional timeout (so keepalivePeriod can be derived from it).
+func (c *ClientConn) captureSessionHeader(res *base.Response) {
+	raw, ok := res.Header["Session"]
+	if !ok {
+		return
+	}
+
+	var sx headers.Session
+	err := sx.Read(raw)
+	if err != nil {
+		return
+	}
+
+	c.session = sx.Session
+	c.sessionTimeout = sx.Timeout
+}
+
+// sessionHeader returns the Session header value to attach to outgoing
+// requests, once a session has been established.
+func (c *ClientConn) sessionHeader() base.HeaderValue {
+	if c.session == "" {
+		return nil
+	}
+	return base.HeaderValue{c.session}
+}
@@ -0,0 +1,134 @@
+package gortsplib
+
+import (
+	"fmt"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"github.com/aler9/gortsplib/pkg/base"
+	"github.com/aler9/gortsplib/pkg/headers"
+	"github.com/aler9/gortsplib/pkg/rtcpreceiver"
+)
+
+// Setup writes a SETUP request and reads a Response.
+// This can be called only after Describe() or Announce().
+func (c *ClientConn) Setup(track *Track, proto StreamProtocol, rtpPort int, rtcpPort int) (*base.Response, error) {
+	err := c.checkState(map[clientConnState]struct{}{
+		clientConnStateInitial:   {},
+		clientConnStatePrePlay:   {},
+		clientConnStatePreRecord: {},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	mode := headers.TransportModePlay
+	if c.state == clientConnStatePreRecord {
+		mode = headers.TransportModeRecord
+	}
+
+	transport := headers.Transport{
+		Protocol: proto,
+		Delivery: func() *base.StreamDelivery {
+			v := base.StreamDeliveryUnicast
+			return &v
+		}(),
+		Mode: &mode,
+	}
+
+	var udpRTPListener, udpRTCPListener *clientUDPListener
+	if proto == StreamProtocolUDP {
+		udpRTPListener, udpRTCPListener, err = newClientUDPListenerPair(rtpPort, rtcpPort,
+			func(payload []byte) {
+				atomic.StoreInt64(c.udpLastFrameTimes[track.ID], time.Now().Unix())
+				c.rtcpReceivers[track.ID].ProcessFrame(time.Now(), StreamTypeRtp, payload)
+				if c.readCB != nil {
+					c.readCB(track.ID, StreamTypeRtp, payload)
+				}
+			},
+			func(payload []byte) {
+				atomic.StoreInt64(c.udpLastFrameTimes[track.ID], time.Now().Unix())
+				c.rtcpReceivers[track.ID].ProcessFrame(time.Now(), StreamTypeRtcp, payload)
+				c.processRTCP(track.ID, payload)
+				if c.readCB != nil {
+					c.readCB(track.ID, StreamTypeRtcp, payload)
+				}
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		transport.ClientPorts = &[2]int{rtpPort, rtcpPort}
+	} else {
+		transport.InterleavedIDs = &[2]int{track.ID * 2, (track.ID * 2) + 1}
+	}
+
+	res, err := c.Do(&base.Request{
+		Method: base.Setup,
+		URL:    track.BaseURL,
+		Header: base.Header{
+			"Transport": transport.Write(),
+			"Session":   c.sessionHeader(),
+		},
+	})
+	if err != nil {
+		if udpRTPListener != nil {
+			udpRTPListener.stop()
+			udpRTCPListener.stop()
+		}
+		return nil, err
+	}
+
+	if res.StatusCode != base.StatusOK {
+		if udpRTPListener != nil {
+			udpRTPListener.stop()
+			udpRTCPListener.stop()
+		}
+		return nil, fmt.Errorf("bad status code: %d (%s)", res.StatusCode, res.StatusMessage)
+	}
+
+	// the Session header is only sent on the first SETUP response, but
+	// some servers resend it (and its timeout) on every one; capture it
+	// every time so the keepalive period always reflects the latest value.
+	c.captureSessionHeader(res)
+
+	if c.tracks == nil {
+		c.tracks = make(map[int]*Track)
+	}
+	c.tracks[track.ID] = track
+
+	if c.rtcpReceivers == nil {
+		c.rtcpReceivers = make(map[int]*rtcpreceiver.RTCPReceiver)
+	}
+	c.rtcpReceivers[track.ID] = rtcpreceiver.New(track.ClockRate())
+
+	if proto == StreamProtocolUDP {
+		if c.udpRtpListeners == nil {
+			c.udpRtpListeners = make(map[int]*clientUDPListener)
+			c.udpRtcpListeners = make(map[int]*clientUDPListener)
+			c.udpLastFrameTimes = make(map[int]*int64)
+		}
+		c.udpRtpListeners[track.ID] = udpRTPListener
+		c.udpRtcpListeners[track.ID] = udpRTCPListener
+		lastFrameTime := time.Now().Unix()
+		c.udpLastFrameTimes[track.ID] = &lastFrameTime
+
+		var rt headers.Transport
+		if tcpAddr, ok := c.nconn.RemoteAddr().(*net.TCPAddr); ok {
+			if err := rt.Read(res.Header["Transport"]); err == nil && rt.ServerPorts != nil {
+				udpRTPListener.setRemoteAddr(tcpAddr.IP, rt.ServerPorts[0])
+				udpRTCPListener.setRemoteAddr(tcpAddr.IP, rt.ServerPorts[1])
+			}
+		}
+	}
+
+	c.streamProtocol = &proto
+
+	if c.state == clientConnStateInitial {
+		c.state = clientConnStatePrePlay
+	}
+
+	return res, nil
+}
@@ -0,0 +1,98 @@
+package gortsplib
+
+import (
+	"net"
+)
+
+// clientUDPReadBufferSize is large enough to hold a full RTP or RTCP
+// packet; UDP datagrams bigger than this are truncated by the kernel
+// on read and dropped here.
+const clientUDPReadBufferSize = 2048
+
+// clientUDPListener owns the UDP socket used to send and receive RTP or
+// RTCP packets for a single track, and delivers every received payload
+// to the callback it was constructed with.
+type clientUDPListener struct {
+	pc         *net.UDPConn
+	remoteAddr *net.UDPAddr
+	onPacket   func(payload []byte)
+
+	readBuf []byte
+	done    chan struct{}
+}
+
+// newClientUDPListenerPair opens the RTP and RTCP sockets for a track.
+// onPacketRTP and onPacketRTCP are called, from a dedicated goroutine
+// started by start(), with the payload of every packet received on the
+// respective socket.
+func newClientUDPListenerPair(rtpPort int, rtcpPort int,
+	onPacketRTP func(payload []byte), onPacketRTCP func(payload []byte)) (*clientUDPListener, *clientUDPListener, error) {
+	rtpListener, err := newClientUDPListener(rtpPort, onPacketRTP)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rtcpListener, err := newClientUDPListener(rtcpPort, onPacketRTCP)
+	if err != nil {
+		rtpListener.stop()
+		return nil, nil, err
+	}
+
+	return rtpListener, rtcpListener, nil
+}
+
+func newClientUDPListener(port int, onPacket func(payload []byte)) (*clientUDPListener, error) {
+	pc, err := net.ListenUDP("udp", &net.UDPAddr{Port: port})
+	if err != nil {
+		return nil, err
+	}
+
+	return &clientUDPListener{
+		pc:       pc,
+		onPacket: onPacket,
+		readBuf:  make([]byte, clientUDPReadBufferSize+1),
+	}, nil
+}
+
+// setRemoteAddr records the server-side address learned from the SETUP
+// response, so write() knows where to send outgoing packets.
+func (l *clientUDPListener) setRemoteAddr(ip net.IP, port int) {
+	l.remoteAddr = &net.UDPAddr{IP: ip, Port: port}
+}
+
+func (l *clientUDPListener) write(payload []byte) {
+	if l.remoteAddr == nil {
+		return
+	}
+	l.pc.WriteTo(payload, l.remoteAddr)
+}
+
+func (l *clientUDPListener) start() {
+	l.done = make(chan struct{})
+	go l.run()
+}
+
+func (l *clientUDPListener) stop() {
+	l.pc.Close()
+	if l.done != nil {
+		<-l.done
+	}
+}
+
+func (l *clientUDPListener) run() {
+	defer close(l.done)
+
+	for {
+		n, err := l.pc.Read(l.readBuf)
+		if err != nil {
+			return
+		}
+
+		if n > clientUDPReadBufferSize {
+			continue
+		}
+
+		payload := append([]byte(nil), l.readBuf[:n]...)
+		l.onPacket(payload)
+	}
+}
@@ -0,0 +1,66 @@
+package headers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aler9/gortsplib/pkg/base"
+)
+
+// Session is a Session header.
+type Session struct {
+	// session id
+	Session string
+
+	// (optional) a timeout, in seconds, after which the session
+	// is closed by the server if no request is received
+	Timeout *uint
+}
+
+// Read decodes a Session header.
+func (h *Session) Read(v base.HeaderValue) error {
+	if len(v) == 0 {
+		return fmt.Errorf("value not provided")
+	}
+
+	if len(v) > 1 {
+		return fmt.Errorf("value provided multiple times (%v)", v)
+	}
+
+	parts := strings.Split(v[0], ";")
+	if len(parts) == 0 {
+		return fmt.Errorf("invalid value (%v)", v)
+	}
+
+	h.Session = parts[0]
+	h.Timeout = nil
+
+	for _, part := range parts[1:] {
+		part = strings.TrimSpace(part)
+		if !strings.HasPrefix(part, "timeout=") {
+			continue
+		}
+
+		tmp, err := strconv.ParseUint(part[len("timeout="):], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid timeout (%v)", v)
+		}
+
+		timeout := uint(tmp)
+		h.Timeout = &timeout
+	}
+
+	return nil
+}
+
+// Write encodes a Session header.
+func (h Session) Write() base.HeaderValue {
+	ret := h.Session
+
+	if h.Timeout != nil {
+		ret += ";timeout=" + strconv.FormatUint(uint64(*h.Timeout), 10)
+	}
+
+	return base.HeaderValue{ret}
+}
@@ -0,0 +1,98 @@
+package headers
+
+import (
+	"testing"
+
+	"github.com/aler9/gortsplib/pkg/base"
+)
+
+func TestSessionRead(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		in   base.HeaderValue
+		want Session
+	}{
+		{
+			"without timeout",
+			base.HeaderValue{"A3EB421A"},
+			Session{Session: "A3EB421A"},
+		},
+		{
+			"with timeout",
+			base.HeaderValue{"A3EB421A;timeout=60"},
+			Session{Session: "A3EB421A", Timeout: func() *uint { v := uint(60); return &v }()},
+		},
+		{
+			"with timeout and spaces",
+			base.HeaderValue{"A3EB421A; timeout=60"},
+			Session{Session: "A3EB421A", Timeout: func() *uint { v := uint(60); return &v }()},
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var h Session
+			err := h.Read(ca.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if h.Session != ca.want.Session {
+				t.Errorf("Session: got %q, want %q", h.Session, ca.want.Session)
+			}
+
+			switch {
+			case ca.want.Timeout == nil && h.Timeout != nil:
+				t.Errorf("Timeout: got %v, want nil", *h.Timeout)
+			case ca.want.Timeout != nil && h.Timeout == nil:
+				t.Errorf("Timeout: got nil, want %v", *ca.want.Timeout)
+			case ca.want.Timeout != nil && h.Timeout != nil && *ca.want.Timeout != *h.Timeout:
+				t.Errorf("Timeout: got %v, want %v", *h.Timeout, *ca.want.Timeout)
+			}
+		})
+	}
+}
+
+func TestSessionReadErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		in   base.HeaderValue
+	}{
+		{"empty", base.HeaderValue{}},
+		{"duplicated", base.HeaderValue{"A3EB421A", "B3EB421A"}},
+		{"invalid timeout", base.HeaderValue{"A3EB421A;timeout=abc"}},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var h Session
+			if err := h.Read(ca.in); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestSessionWrite(t *testing.T) {
+	timeout := uint(60)
+
+	for _, ca := range []struct {
+		name string
+		in   Session
+		want base.HeaderValue
+	}{
+		{
+			"without timeout",
+			Session{Session: "A3EB421A"},
+			base.HeaderValue{"A3EB421A"},
+		},
+		{
+			"with timeout",
+			Session{Session: "A3EB421A", Timeout: &timeout},
+			base.HeaderValue{"A3EB421A;timeout=60"},
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			got := ca.in.Write()
+			if len(got) != 1 || got[0] != ca.want[0] {
+				t.Errorf("got %v, want %v", got, ca.want)
+			}
+		})
+	}
+}
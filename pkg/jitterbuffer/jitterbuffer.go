@@ -0,0 +1,204 @@
+// Package jitterbuffer implements a per-track RTP reordering buffer,
+// used to smooth out out-of-order and duplicate UDP arrivals before
+// packets reach the user callback.
+package jitterbuffer
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// Counters contains the statistics collected by a JitterBuffer.
+type Counters struct {
+	// packets that arrived out of sequence order.
+	Reordered uint64
+
+	// packets that were dropped because a packet with the same
+	// sequence number was already buffered.
+	Duplicated uint64
+
+	// gaps that were never filled and were released as losses.
+	Lost uint64
+
+	// packets that arrived too late to be reordered and were dropped.
+	LateDropped uint64
+}
+
+type item struct {
+	extSeq  uint64
+	arrival time.Time
+	payload []byte
+}
+
+type itemHeap []*item
+
+func (h itemHeap) Len() int            { return len(h) }
+func (h itemHeap) Less(i, j int) bool  { return h[i].extSeq < h[j].extSeq }
+func (h itemHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *itemHeap) Push(x interface{}) { *h = append(*h, x.(*item)) }
+func (h *itemHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	it := old[n-1]
+	*h = old[:n-1]
+	return it
+}
+
+// JitterBuffer reorders RTP packets of a single track by extended
+// sequence number, releasing them in order through onRelease.
+// A nil payload passed to onRelease marks a packet that was never
+// received (PacketLost), so that decoders can conceal it.
+type JitterBuffer struct {
+	size      int
+	maxDelay  time.Duration
+	onRelease func(payload []byte)
+
+	mutex       sync.Mutex
+	heap        itemHeap
+	present     map[uint64]struct{}
+	initialized bool
+	lastSeq     uint16
+	wraps       uint64
+	nextExt     uint64
+	counters    Counters
+}
+
+// New allocates a JitterBuffer.
+// size is the maximum number of packets that can be buffered;
+// maxDelay is the time after which a held packet is released even if
+// older gaps have not been filled yet. This deadline is checked only when
+// Push() or Flush() is called: a held packet is not released while no
+// further packet arrives.
+func New(size int, maxDelay time.Duration, onRelease func(payload []byte)) *JitterBuffer {
+	return &JitterBuffer{
+		size:      size,
+		maxDelay:  maxDelay,
+		onRelease: onRelease,
+		present:   make(map[uint64]struct{}),
+	}
+}
+
+// Stats returns a snapshot of the counters collected so far.
+// It is safe to call concurrently with Push()/Flush().
+func (b *JitterBuffer) Stats() Counters {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.counters
+}
+
+// Push inserts a RTP packet, identified by its 16-bit sequence number,
+// into the buffer, releasing any packet whose ordering is now resolved.
+func (b *JitterBuffer) Push(seq uint16, payload []byte) {
+	b.mutex.Lock()
+
+	ext := b.extend(seq)
+
+	if !b.initialized {
+		b.initialized = true
+		b.nextExt = ext
+	}
+
+	if ext < b.nextExt {
+		b.counters.LateDropped++
+		b.mutex.Unlock()
+		return
+	}
+
+	if _, ok := b.present[ext]; ok {
+		b.counters.Duplicated++
+		b.mutex.Unlock()
+		return
+	}
+
+	if ext != b.nextExt {
+		b.counters.Reordered++
+	}
+
+	b.present[ext] = struct{}{}
+	heap.Push(&b.heap, &item{extSeq: ext, arrival: time.Now(), payload: payload})
+
+	released := b.release(false)
+	b.mutex.Unlock()
+
+	for _, payload := range released {
+		b.onRelease(payload)
+	}
+}
+
+// Flush releases every buffered packet, filling any remaining gap with
+// a loss marker. It must be called once, on shutdown, before the last
+// call to onRelease returns.
+func (b *JitterBuffer) Flush() {
+	b.mutex.Lock()
+	released := b.release(true)
+	b.mutex.Unlock()
+
+	for _, payload := range released {
+		b.onRelease(payload)
+	}
+}
+
+// release drains the heap collecting in-order packets to release, plus:
+//   - older packets once the buffer grows past size;
+//   - packets whose deadline (arrival + maxDelay) has elapsed by the time
+//     release is called;
+//   - every remaining packet, if all is true.
+//
+// any gap found while draining is collected as a loss marker (nil payload).
+// release only mutates buffer state; the caller must invoke onRelease for
+// each returned payload itself, after unlocking b.mutex, since onRelease
+// chains into the user's callback and must not be called while held (a
+// callback calling back into Stats() would otherwise deadlock).
+func (b *JitterBuffer) release(all bool) [][]byte {
+	now := time.Now()
+	var released [][]byte
+
+	for len(b.heap) > 0 {
+		next := b.heap[0]
+
+		full := b.size > 0 && len(b.heap) > b.size
+		expired := b.maxDelay > 0 && now.Sub(next.arrival) >= b.maxDelay
+
+		if next.extSeq != b.nextExt && !full && !expired && !all {
+			return released
+		}
+
+		if next.extSeq != b.nextExt {
+			b.counters.Lost++
+			released = append(released, nil)
+			b.nextExt++
+			continue
+		}
+
+		heap.Pop(&b.heap)
+		delete(b.present, next.extSeq)
+		released = append(released, next.payload)
+		b.nextExt++
+	}
+
+	return released
+}
+
+// extend maps a 16-bit RTP sequence number onto a monotonically
+// increasing 32-bit-extended space, tracking wraparounds.
+func (b *JitterBuffer) extend(seq uint16) uint64 {
+	if !b.initialized {
+		b.lastSeq = seq
+		return uint64(seq)
+	}
+
+	// a wraparound occurred if the new sequence number is much smaller
+	// than the last one seen (e.g. 0x0001 after 0xfffe).
+	if seq < b.lastSeq && (b.lastSeq-seq) > 0x8000 {
+		b.wraps++
+	} else if seq > b.lastSeq && (seq-b.lastSeq) > 0x8000 && b.wraps > 0 {
+		// a late, very old packet from before the last wraparound.
+		return (b.wraps-1)<<16 | uint64(seq)
+	}
+
+	b.lastSeq = seq
+
+	return b.wraps<<16 | uint64(seq)
+}
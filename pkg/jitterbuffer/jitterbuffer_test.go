@@ -0,0 +1,237 @@
+package jitterbuffer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJitterBufferInOrder(t *testing.T) {
+	var released [][]byte
+	b := New(10, 0, func(payload []byte) {
+		released = append(released, payload)
+	})
+
+	for _, seq := range []uint16{0, 1, 2, 3} {
+		b.Push(seq, []byte{byte(seq)})
+	}
+
+	if len(released) != 4 {
+		t.Fatalf("got %d released packets, want 4", len(released))
+	}
+	for i, payload := range released {
+		if payload == nil || payload[0] != byte(i) {
+			t.Errorf("released[%d] = %v, want [%d]", i, payload, i)
+		}
+	}
+
+	stats := b.Stats()
+	if stats.Reordered != 0 || stats.Duplicated != 0 || stats.Lost != 0 || stats.LateDropped != 0 {
+		t.Errorf("unexpected counters for in-order stream: %+v", stats)
+	}
+}
+
+func TestJitterBufferReordered(t *testing.T) {
+	var released []int
+
+	b := New(10, 0, func(payload []byte) {
+		if payload == nil {
+			released = append(released, -1)
+			return
+		}
+		released = append(released, int(payload[0]))
+	})
+
+	// 0, 2, 1, 3 arrives; the buffer holds 2 back until 1 fills the gap.
+	b.Push(0, []byte{0})
+	b.Push(2, []byte{2})
+	b.Push(1, []byte{1})
+	b.Push(3, []byte{3})
+
+	want := []int{0, 1, 2, 3}
+	if len(released) != len(want) {
+		t.Fatalf("got %v, want %v", released, want)
+	}
+	for i := range want {
+		if released[i] != want[i] {
+			t.Errorf("released[%d] = %d, want %d", i, released[i], want[i])
+		}
+	}
+
+	if b.Stats().Reordered == 0 {
+		t.Error("expected Reordered to be incremented")
+	}
+}
+
+func TestJitterBufferDuplicate(t *testing.T) {
+	count := 0
+	b := New(10, 0, func(payload []byte) { count++ })
+
+	b.Push(0, []byte{0}) // released immediately, establishes nextExt=1
+	b.Push(2, []byte{2}) // gap at 1: held in the buffer
+	b.Push(2, []byte{2}) // duplicate of the still-buffered packet
+
+	if count != 1 {
+		t.Errorf("got %d releases, want 1", count)
+	}
+	if b.Stats().Duplicated != 1 {
+		t.Errorf("Duplicated = %d, want 1", b.Stats().Duplicated)
+	}
+}
+
+func TestJitterBufferLateDropped(t *testing.T) {
+	b := New(10, 0, func(payload []byte) {})
+
+	b.Push(5, []byte{5}) // released immediately, establishes nextExt=6
+	b.Push(3, []byte{3}) // older than nextExt: dropped as late
+
+	if b.Stats().LateDropped != 1 {
+		t.Errorf("LateDropped = %d, want 1", b.Stats().LateDropped)
+	}
+}
+
+func TestJitterBufferSizeForcesRelease(t *testing.T) {
+	var released []int
+	b := New(2, 0, func(payload []byte) {
+		if payload == nil {
+			released = append(released, -1)
+			return
+		}
+		released = append(released, int(payload[0]))
+	})
+
+	b.Push(0, []byte{0}) // establishes nextExt=0, released immediately
+	b.Push(2, []byte{2}) // gap at 1
+	b.Push(3, []byte{3})
+	b.Push(4, []byte{4}) // buffer now holds 3 packets, past size 2: gap forced out
+
+	want := []int{0, -1, 2, 3, 4}
+	if len(released) != len(want) {
+		t.Fatalf("got %v, want %v", released, want)
+	}
+	for i := range want {
+		if released[i] != want[i] {
+			t.Errorf("released[%d] = %d, want %d", i, released[i], want[i])
+		}
+	}
+	if b.Stats().Lost != 1 {
+		t.Errorf("Lost = %d, want 1", b.Stats().Lost)
+	}
+}
+
+func TestJitterBufferMaxDelayForcesRelease(t *testing.T) {
+	var released []int
+	b := New(100, 10*time.Millisecond, func(payload []byte) {
+		if payload == nil {
+			released = append(released, -1)
+			return
+		}
+		released = append(released, int(payload[0]))
+	})
+
+	b.Push(0, []byte{0}) // establishes nextExt=0, released immediately
+	b.Push(2, []byte{2}) // gap at 1, held back waiting for reordering
+	time.Sleep(20 * time.Millisecond)
+	b.Push(3, []byte{3}) // triggers the deadline check on the held packet
+
+	want := []int{0, -1, 2, 3}
+	if len(released) != len(want) {
+		t.Fatalf("got %v, want %v", released, want)
+	}
+	for i := range want {
+		if released[i] != want[i] {
+			t.Errorf("released[%d] = %d, want %d", i, released[i], want[i])
+		}
+	}
+}
+
+func TestJitterBufferFlush(t *testing.T) {
+	var released []int
+	b := New(100, 0, func(payload []byte) {
+		if payload == nil {
+			released = append(released, -1)
+			return
+		}
+		released = append(released, int(payload[0]))
+	})
+
+	b.Push(0, []byte{0})
+	b.Push(2, []byte{2}) // gap at 1, not yet released
+
+	if len(released) != 1 {
+		t.Fatalf("got %d released before Flush, want 1", len(released))
+	}
+
+	b.Flush()
+
+	want := []int{0, -1, 2}
+	if len(released) != len(want) {
+		t.Fatalf("got %v after Flush, want %v", released, want)
+	}
+	for i := range want {
+		if released[i] != want[i] {
+			t.Errorf("released[%d] = %d, want %d", i, released[i], want[i])
+		}
+	}
+}
+
+func TestJitterBufferOnReleaseCanCallStats(t *testing.T) {
+	var b *JitterBuffer
+	b = New(10, 0, func(payload []byte) {
+		// a callback calling back into Stats() is the exact pattern
+		// TrackStats() enables for a jitter-buffered track; onRelease
+		// must not be called while b.mutex is held, or this deadlocks.
+		b.Stats()
+	})
+
+	done := make(chan struct{})
+	go func() {
+		b.Push(0, []byte{0})
+		b.Push(1, []byte{1})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Push() deadlocked when onRelease called Stats()")
+	}
+}
+
+func TestExtendWraparound(t *testing.T) {
+	b := &JitterBuffer{present: make(map[uint64]struct{})}
+
+	first := b.extend(0xfffe)
+	if first != 0xfffe {
+		t.Fatalf("first extend = %x, want 0xfffe", first)
+	}
+	// Push() always marks the buffer initialized right after the first
+	// extend() call; replicate that here since this test calls extend()
+	// directly.
+	b.initialized = true
+
+	second := b.extend(0x0002)
+	if second != 0x10002 {
+		t.Fatalf("second extend = %x, want 0x10002 (wraparound not detected)", second)
+	}
+
+	// a late packet from before the wraparound must not underflow.
+	late := b.extend(0xfffd)
+	if late != 0xfffd {
+		t.Fatalf("late pre-wraparound extend = %x, want 0xfffd", late)
+	}
+}
+
+func TestExtendNoWraparoundYet(t *testing.T) {
+	b := &JitterBuffer{present: make(map[uint64]struct{})}
+
+	b.extend(10)
+	b.initialized = true
+
+	// a stale, much-larger sequence number arriving before any real
+	// wraparound has occurred must not be treated as pre-wraparound
+	// (which would previously underflow b.wraps-1 on a uint64).
+	got := b.extend(65300)
+	if got != 65300 {
+		t.Fatalf("extend(65300) = %x, want 65300 (no wraparound has occurred yet)", got)
+	}
+}
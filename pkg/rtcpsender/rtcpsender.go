@@ -0,0 +1,95 @@
+// Package rtcpsender contains a utility to generate RTCP sender reports
+// for an outgoing RTP stream, symmetric to pkg/rtcpreceiver.
+package rtcpsender
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
+)
+
+// ntpEpochOffset is the number of seconds between the NTP epoch (1900)
+// and the Unix epoch (1970).
+const ntpEpochOffset = 2208988800
+
+// RTCPSender accumulates statistics about an outgoing RTP stream and
+// generates RTCP sender reports out of them.
+type RTCPSender struct {
+	clockRate uint32
+
+	mutex       sync.Mutex
+	initialized bool
+	ssrc        uint32
+	lastRTPTime uint32
+	lastNTPTime time.Time
+	packetCount uint32
+	octetCount  uint32
+}
+
+// New allocates a RTCPSender.
+func New(clockRate uint32) *RTCPSender {
+	return &RTCPSender{clockRate: clockRate}
+}
+
+// ProcessPacket updates the sender statistics with an outgoing RTP packet,
+// read from its wire representation.
+func (s *RTCPSender) ProcessPacket(now time.Time, payload []byte) error {
+	var pkt rtp.Packet
+	err := pkt.Unmarshal(payload)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.initialized = true
+	s.ssrc = pkt.SSRC
+	s.lastRTPTime = pkt.Timestamp
+	s.lastNTPTime = now
+	s.packetCount++
+	s.octetCount += uint32(len(pkt.Payload))
+
+	return nil
+}
+
+// Report returns a marshaled RTCP sender report, or nil if no RTP packet
+// has been processed yet.
+func (s *RTCPSender) Report(now time.Time) []byte {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !s.initialized {
+		return nil
+	}
+
+	// extrapolate the RTP timestamp forward from the last processed packet
+	// to the time this report is actually generated, otherwise it would be
+	// paired with a NTP time that is seconds ahead of it and throw off
+	// NTP/RTP sync on the receiving end.
+	elapsed := now.Sub(s.lastNTPTime)
+	rtpTime := s.lastRTPTime + uint32(elapsed.Seconds()*float64(s.clockRate))
+
+	sr := &rtcp.SenderReport{
+		SSRC:        s.ssrc,
+		NTPTime:     toNTPTime(now),
+		RTPTime:     rtpTime,
+		PacketCount: s.packetCount,
+		OctetCount:  s.octetCount,
+	}
+
+	byts, err := sr.Marshal()
+	if err != nil {
+		return nil
+	}
+
+	return byts
+}
+
+func toNTPTime(t time.Time) uint64 {
+	sec := uint64(t.Unix()) + ntpEpochOffset
+	frac := (uint64(t.Nanosecond()) << 32) / 1e9
+	return sec<<32 | frac
+}
@@ -0,0 +1,74 @@
+package rtcpsender
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
+)
+
+func TestRTCPSenderReportNil(t *testing.T) {
+	s := New(90000)
+
+	if r := s.Report(time.Now()); r != nil {
+		t.Fatalf("Report() = %v before any packet was processed, want nil", r)
+	}
+}
+
+func TestRTCPSenderReportExtrapolatesRTPTime(t *testing.T) {
+	s := New(90000)
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	pkt := rtp.Packet{
+		Header:  rtp.Header{SSRC: 123, Timestamp: 1000},
+		Payload: []byte{1, 2, 3},
+	}
+	payload, err := pkt.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal() failed: %v", err)
+	}
+
+	if err := s.ProcessPacket(start, payload); err != nil {
+		t.Fatalf("ProcessPacket() failed: %v", err)
+	}
+
+	// the report is generated 2 seconds after the last RTP packet was
+	// processed; RTPTime must be extrapolated forward by clockRate * 2,
+	// not left pinned to the last packet's raw timestamp.
+	now := start.Add(2 * time.Second)
+	byts := s.Report(now)
+	if byts == nil {
+		t.Fatal("Report() = nil, want a marshaled sender report")
+	}
+
+	var pkt2 rtcp.SenderReport
+	if err := pkt2.Unmarshal(byts); err != nil {
+		t.Fatalf("Unmarshal() failed: %v", err)
+	}
+
+	wantRTPTime := uint32(1000) + 2*90000
+	if pkt2.RTPTime != wantRTPTime {
+		t.Errorf("RTPTime = %d, want %d", pkt2.RTPTime, wantRTPTime)
+	}
+	if pkt2.SSRC != 123 {
+		t.Errorf("SSRC = %d, want 123", pkt2.SSRC)
+	}
+	if pkt2.PacketCount != 1 {
+		t.Errorf("PacketCount = %d, want 1", pkt2.PacketCount)
+	}
+}
+
+func TestToNTPTime(t *testing.T) {
+	// 2020-01-01T00:00:00Z is a known offset from the NTP epoch (1900).
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	got := toNTPTime(tm)
+
+	wantSec := uint64(tm.Unix()) + ntpEpochOffset
+	if sec := got >> 32; sec != wantSec {
+		t.Errorf("toNTPTime() seconds = %d, want %d", sec, wantSec)
+	}
+	if frac := got & 0xffffffff; frac != 0 {
+		t.Errorf("toNTPTime() fraction = %d, want 0 for a whole second", frac)
+	}
+}
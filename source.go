@@ -0,0 +1,179 @@
+package gortsplib
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aler9/gortsplib/pkg/base"
+)
+
+// SourceConf allows to configure a Source.
+type SourceConf struct {
+	// URL of the stream to read.
+	URL *base.URL
+
+	// the protocol to use, automatic if nil.
+	StreamProtocol *StreamProtocol
+
+	// timeout of read operations.
+	// It defaults to 10 seconds.
+	ReadTimeout time.Duration
+
+	// timeout of write operations.
+	// It defaults to 10 seconds.
+	WriteTimeout time.Duration
+
+	// initial pause between a disconnection and the next reconnection
+	// attempt. It doubles on every consecutive failure, up to RetryPauseMax.
+	// It defaults to 2 seconds.
+	RetryPause time.Duration
+
+	// maximum pause reached by the exponential backoff.
+	// It defaults to 30 seconds.
+	RetryPauseMax time.Duration
+
+	// called when the tracks of the stream have been read.
+	OnTracks func(Tracks)
+
+	// called when a frame is received.
+	OnFrame func(trackID int, streamType StreamType, payload []byte)
+
+	// called after a successful connection.
+	OnConnected func()
+
+	// called after a disconnection, with the error that caused it.
+	OnDisconnected func(err error)
+}
+
+// Source is a wrapper around ClientConn that connects to a RTSP source,
+// reads its tracks and forwards its frames, reconnecting automatically
+// in case of error.
+type Source struct {
+	conf SourceConf
+
+	terminate chan struct{}
+	done      chan struct{}
+}
+
+// NewSource allocates a Source.
+func NewSource(conf SourceConf) *Source {
+	if conf.ReadTimeout == 0 {
+		conf.ReadTimeout = 10 * time.Second
+	}
+	if conf.WriteTimeout == 0 {
+		conf.WriteTimeout = 10 * time.Second
+	}
+	if conf.RetryPause == 0 {
+		conf.RetryPause = 2 * time.Second
+	}
+	if conf.RetryPauseMax == 0 {
+		conf.RetryPauseMax = 30 * time.Second
+	}
+
+	s := &Source{
+		conf:      conf,
+		terminate: make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+
+	go s.run()
+
+	return s
+}
+
+// Close closes a Source and waits for its goroutine to return.
+func (s *Source) Close() {
+	close(s.terminate)
+	<-s.done
+}
+
+func (s *Source) run() {
+	defer close(s.done)
+
+	// zero, so that nextRetryPause() returns RetryPause after the first failure
+	var pause time.Duration
+
+	for {
+		connectedAt := time.Now()
+		err := s.runInner()
+		if err == errTerminated {
+			return
+		}
+
+		if s.conf.OnDisconnected != nil {
+			s.conf.OnDisconnected(err)
+		}
+
+		pause = nextRetryPause(pause, time.Since(connectedAt), s.conf.RetryPause, s.conf.RetryPauseMax)
+
+		select {
+		case <-time.After(pause):
+		case <-s.terminate:
+			return
+		}
+	}
+}
+
+var errTerminated = fmt.Errorf("terminated")
+
+// nextRetryPause computes the backoff pause to wait before the next
+// reconnection attempt. A connection that stayed up for at least a full
+// backoff period is considered stable again, so the pause resets instead
+// of growing forever; otherwise it doubles, up to max.
+func nextRetryPause(pause, uptime, base, max time.Duration) time.Duration {
+	if uptime >= pause {
+		return base
+	}
+
+	pause *= 2
+	if pause > max {
+		pause = max
+	}
+	return pause
+}
+
+func (s *Source) runInner() error {
+	conf := ClientConf{
+		StreamProtocol: s.conf.StreamProtocol,
+		ReadTimeout:    s.conf.ReadTimeout,
+		WriteTimeout:   s.conf.WriteTimeout,
+	}
+
+	conn, err := conf.DialRead(s.conf.URL.String())
+	if err != nil {
+		return err
+	}
+
+	closeOnce := sync.Once{}
+	closeConn := func() {
+		closeOnce.Do(func() {
+			conn.Close()
+		})
+	}
+	defer closeConn()
+
+	if s.conf.OnTracks != nil {
+		s.conf.OnTracks(conn.Tracks())
+	}
+
+	if s.conf.OnConnected != nil {
+		s.conf.OnConnected()
+	}
+
+	onFrameDone := conn.OnFrame(func(trackID int, streamType StreamType, payload []byte) {
+		if s.conf.OnFrame != nil {
+			s.conf.OnFrame(trackID, streamType, payload)
+		}
+	})
+
+	select {
+	case err := <-onFrameDone:
+		return err
+
+	case <-s.terminate:
+		closeConn()
+		<-onFrameDone
+		return errTerminated
+	}
+}
@@ -0,0 +1,37 @@
+package gortsplib
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextRetryPauseResetsAfterStableUptime(t *testing.T) {
+	base := 2 * time.Second
+	max := 30 * time.Second
+
+	got := nextRetryPause(8*time.Second, 10*time.Second, base, max)
+	if got != base {
+		t.Errorf("nextRetryPause() = %v, want %v (reset after stable uptime)", got, base)
+	}
+}
+
+func TestNextRetryPauseDoublesOnQuickFailure(t *testing.T) {
+	base := 2 * time.Second
+	max := 30 * time.Second
+
+	got := nextRetryPause(4*time.Second, 1*time.Second, base, max)
+	want := 8 * time.Second
+	if got != want {
+		t.Errorf("nextRetryPause() = %v, want %v", got, want)
+	}
+}
+
+func TestNextRetryPauseCapsAtMax(t *testing.T) {
+	base := 2 * time.Second
+	max := 30 * time.Second
+
+	got := nextRetryPause(20*time.Second, 1*time.Second, base, max)
+	if got != max {
+		t.Errorf("nextRetryPause() = %v, want %v (capped at max)", got, max)
+	}
+}